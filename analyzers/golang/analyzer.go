@@ -0,0 +1,134 @@
+// Package golang implements analysis for Go projects using the standard
+// library's go/build tooling together with resolvers for the various Go
+// dependency management tools in common use.
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/fossas/fossa-cli/analyzers/golang/resolver"
+	"github.com/fossas/fossa-cli/files"
+)
+
+// An Analyzer analyzes Go packages.
+type Analyzer struct {
+	// projectCache memoizes Project lookups by package import path.
+	projectCache map[string]Project
+}
+
+// New creates a new Analyzer.
+func New() *Analyzer {
+	return &Analyzer{
+		projectCache: make(map[string]Project),
+	}
+}
+
+// Dir returns the absolute directory of the Go package with import path pkg,
+// computed relative to $GOPATH/src.
+func (a *Analyzer) Dir(pkg string) (string, error) {
+	if os.Getenv("GOPATH") == "" {
+		return "", errors.New("no $GOPATH set")
+	}
+	gopath, err := filepath.Abs(os.Getenv("GOPATH"))
+	if err != nil {
+		return "", errors.Wrap(err, "could not get absolute $GOPATH")
+	}
+	return filepath.Join(gopath, "src", pkg), nil
+}
+
+// NearestLockfile searches upward from dir for the manifest of a supported
+// Go dependency management tool, returning the tool's Type and the
+// directory containing its manifest.
+//
+// Tools are checked in the order below at each directory level before
+// ascending to the parent, so that a closer, more specific manifest is
+// always preferred over one further up the tree. go.mod is checked first:
+// it is the module root as far as the `go` tool is concerned, so a legacy
+// lockfile further down the same directory would be misleading.
+func NearestLockfile(dir string) (resolver.Type, string, error) {
+	current := dir
+	for {
+		r := eitherStr{}
+		r.Find(resolver.GoModules.String(), current, "go.mod")
+		r.Find(resolver.Dep.String(), current, "Gopkg.toml")
+		r.Find(resolver.Godep.String(), current, "Godeps", "Godeps.json")
+		r.FindFolder(resolver.Govendor.String(), current, "vendor")
+		r.Find(resolver.Glide.String(), current, "glide.yaml")
+		r.Find(resolver.Vndr.String(), current, "vendor.conf")
+		r.Find(resolver.Gdm.String(), current, "Godeps")
+		if r.err != nil {
+			return resolver.Gopath, "", r.err
+		}
+		if r.result != "" {
+			return toolType(r.result), current, nil
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return resolver.Gopath, dir, nil
+		}
+		current = parent
+	}
+}
+
+func toolType(name string) resolver.Type {
+	switch name {
+	case "gomodules":
+		return resolver.GoModules
+	case "dep":
+		return resolver.Dep
+	case "godep":
+		return resolver.Godep
+	case "govendor":
+		return resolver.Govendor
+	case "glide":
+		return resolver.Glide
+	case "vndr":
+		return resolver.Vndr
+	case "gdm":
+		return resolver.Gdm
+	default:
+		return resolver.Gopath
+	}
+}
+
+// NearestVCS searches upward from dir for the root of a VCS repository,
+// returning the VCS type ("git", "hg", "bzr", "svn") and the repository
+// root. If no VCS repository is found, it returns dir as the root.
+func NearestVCS(dir string) (string, string, error) {
+	current := dir
+	for {
+		for _, vcs := range []string{"git", "hg", "bzr", "svn"} {
+			ok, err := files.ExistsFolder(current, "."+vcs)
+			if err != nil {
+				return "", "", err
+			}
+			if ok {
+				return vcs, current, nil
+			}
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", dir, nil
+		}
+		current = parent
+	}
+}
+
+// VendorParent returns the directory containing the nearest "vendor" folder
+// that dir is nested within, or dir itself if dir is not nested within a
+// vendor folder.
+func VendorParent(dir string) string {
+	elems := strings.Split(dir, string(filepath.Separator))
+	for i := len(elems) - 1; i >= 0; i-- {
+		if elems[i] == "vendor" {
+			return filepath.Join(string(filepath.Separator), filepath.Join(elems[:i]...))
+		}
+	}
+	return dir
+}
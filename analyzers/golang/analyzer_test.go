@@ -0,0 +1,36 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossas/fossa-cli/analyzers/golang/resolver"
+)
+
+func TestNearestLockfilePrefersGoModOverVendorFolder(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/fossas/fixture\n"), 0o600)
+	assert.NoError(t, err)
+	err = os.MkdirAll(filepath.Join(dir, "vendor"), 0o755)
+	assert.NoError(t, err)
+
+	tool, manifestDir, err := NearestLockfile(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, resolver.GoModules, tool)
+	assert.Equal(t, dir, manifestDir)
+}
+
+func TestNearestLockfilePrefersGoModOverGopkgToml(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/fossas/fixture\n"), 0o600)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "Gopkg.toml"), []byte(""), 0o600)
+	assert.NoError(t, err)
+
+	tool, _, err := NearestLockfile(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, resolver.GoModules, tool)
+}
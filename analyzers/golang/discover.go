@@ -0,0 +1,74 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/fossas/fossa-cli/analyzers/golang/resolver"
+)
+
+// A ModulesProject is a discovered Go modules project: the module's own
+// import path, plus its fully-resolved, revision-locked dependencies.
+type ModulesProject struct {
+	Dir        string
+	ImportPath string
+	Deps       []resolver.LockedDependency
+}
+
+// Discover walks dir looking for Go modules projects, delegating to
+// DiscoverModules. This is the entry point callers should use to find every
+// go.mod-based project within a directory tree, the go-modules counterpart
+// of the legacy dep/glide/godep discovery paths.
+func (a *Analyzer) Discover(dir string) ([]ModulesProject, error) {
+	return DiscoverModules(dir)
+}
+
+// DiscoverModules walks dir looking for go.mod files and, for each one
+// found, resolves its dependencies via resolver.Resolve. This is the
+// go-modules counterpart of the legacy dep/glide/godep discovery paths:
+// callers that walk the repository looking for buildable projects should
+// call this alongside the legacy discovery strategies and prefer its
+// results wherever a go.mod is present, per Analyzer.Project.
+func DiscoverModules(dir string) ([]ModulesProject, error) {
+	var projects []ModulesProject
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == "vendor" {
+			return filepath.SkipDir
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if info.Name() != "go.mod" {
+			return nil
+		}
+
+		modDir := filepath.Dir(path)
+		importPath, err := moduleImportPath(modDir)
+		if err != nil {
+			return errors.Wrapf(err, "could not discover go.mod project at %s", modDir)
+		}
+
+		deps, err := resolver.Resolve(modDir)
+		if err != nil {
+			return errors.Wrapf(err, "could not resolve go.mod dependencies at %s", modDir)
+		}
+
+		projects = append(projects, ModulesProject{
+			Dir:        modDir,
+			ImportPath: importPath,
+			Deps:       deps,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
@@ -0,0 +1,76 @@
+package golang
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossas/fossa-cli/analyzers/golang/resolver"
+)
+
+// TestMain forces resolver.Resolve down its go.mod-only fallback path for
+// this package's whole test suite, instead of depending on the real `go`
+// tool failing to resolve fixture modules that don't exist -- that
+// reliance on an external command's network/module-cache behavior isn't a
+// proper test boundary.
+func TestMain(m *testing.M) {
+	resolver.ListModulesRunner = func(dir string) ([]byte, error) {
+		return nil, errors.New("go list disabled in tests")
+	}
+	os.Exit(m.Run())
+}
+
+// TestDiscoverFindsReplacedDependencies is an integration test of
+// Analyzer.Discover (and, through it, DiscoverModules + resolver.Resolve)
+// against a fixture module that replaces one dependency with a local path
+// and another with a fork.
+func TestDiscoverFindsReplacedDependencies(t *testing.T) {
+	root := t.TempDir()
+	modDir := filepath.Join(root, "proj")
+	assert.NoError(t, os.MkdirAll(modDir, 0o755))
+
+	contents := `module github.com/fossas/fixture
+
+go 1.14
+
+require (
+	github.com/pkg/errors v0.9.1
+	github.com/example/original v1.2.3
+	github.com/fossas/fixture/local v0.0.0-00010101000000-000000000000
+)
+
+replace github.com/fossas/fixture/local => ../local
+
+replace github.com/example/original => github.com/example/fork v1.2.4
+`
+	err := os.WriteFile(filepath.Join(modDir, "go.mod"), []byte(contents), 0o600)
+	assert.NoError(t, err)
+
+	projects, err := New().Discover(root)
+	assert.NoError(t, err)
+	assert.Len(t, projects, 1)
+
+	project := projects[0]
+	assert.Equal(t, "github.com/fossas/fixture", project.ImportPath)
+	assert.Equal(t, modDir, project.Dir)
+
+	deps := make(map[string]resolverDep, len(project.Deps))
+	for _, dep := range project.Deps {
+		deps[dep.ImportPath] = resolverDep{Revision: dep.Revision, ReplacedBy: dep.ReplacedBy, LocalPath: dep.LocalPath}
+	}
+
+	assert.Equal(t, resolverDep{Revision: "v0.9.1"}, deps["github.com/pkg/errors"])
+	assert.Equal(t, resolverDep{Revision: "v1.2.4", ReplacedBy: "github.com/example/fork"}, deps["github.com/example/original"])
+	assert.Equal(t, resolverDep{LocalPath: "../local"}, deps["github.com/fossas/fixture/local"])
+}
+
+// resolverDep is a trimmed-down comparison shape for resolver.LockedDependency
+// that leaves ImportPath out, since it's already used as the map key above.
+type resolverDep struct {
+	Revision   string
+	ReplacedBy string
+	LocalPath  string
+}
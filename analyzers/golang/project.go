@@ -11,6 +11,25 @@ import (
 	"github.com/fossas/fossa-cli/files"
 )
 
+// moduleImportPath returns the module path declared by the `module`
+// directive of the go.mod file at manifestDir. This is the import path
+// prefix for all packages in the module, regardless of where the module
+// lives relative to $GOPATH.
+func moduleImportPath(manifestDir string) (string, error) {
+	contents, err := files.Read(manifestDir, "go.mod")
+	if err != nil {
+		return "", errors.Wrap(err, "could not read go.mod")
+	}
+
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module")), nil
+		}
+	}
+	return "", errors.Errorf("go.mod at %s has no module directive", manifestDir)
+}
+
 // A Project is a single folder that forms a coherent "project" for a developer
 // and is versioned as a single unit. It may contain multiple Go packages.
 type Project struct {
@@ -83,22 +102,56 @@ func (a *Analyzer) Project(pkg string) (Project, error) {
 	parent := VendorParent(dir)
 
 	// Project root is the lower of the nearest VCS or the vendor parent.
-	projectDir := repoRoot
-	if strings.HasPrefix(parent, repoRoot) {
-		projectDir = parent
-	}
-
-	// Compute the project import path prefix.
-	if os.Getenv("GOPATH") == "" {
-		return Project{}, errors.New("no $GOPATH set")
-	}
-	gopath, err := filepath.Abs(os.Getenv("GOPATH"))
+	//
+	// This comparison is done on canonical, symlink-resolved paths: a
+	// checkout that lives outside $GOPATH and is symlinked into
+	// $GOPATH/src is a common setup, and repoRoot and parent may disagree
+	// about which symlink(s), if any, they were reached through even
+	// though they describe the same place on disk. A mapping from
+	// canonical back to original path is kept so that the chosen project
+	// root is reported using the path the user actually gave us, not
+	// wherever the symlink resolves to.
+	canonicalRepoRoot, err := filepath.EvalSymlinks(repoRoot)
 	if err != nil {
-		return Project{}, errors.Wrap(err, "could not get absolute $GOPATH")
+		return Project{}, errors.Wrap(err, "could not resolve symlinks for VCS root")
 	}
-	importPrefix, err := filepath.Rel(filepath.Join(gopath, "src"), projectDir)
+	canonicalParent, err := filepath.EvalSymlinks(parent)
 	if err != nil {
-		return Project{}, errors.Wrap(err, "could not compute import prefix")
+		return Project{}, errors.Wrap(err, "could not resolve symlinks for vendor parent")
+	}
+	originalOf := map[string]string{
+		canonicalRepoRoot: repoRoot,
+		canonicalParent:   parent,
+	}
+
+	canonicalProjectDir := canonicalRepoRoot
+	if strings.HasPrefix(canonicalParent, canonicalRepoRoot) {
+		canonicalProjectDir = canonicalParent
+	}
+	projectDir := originalOf[canonicalProjectDir]
+
+	// Compute the project import path prefix. Go modules are the source of
+	// truth for their own import path, so projects that live outside
+	// $GOPATH/src (as modules commonly do) are handled without consulting
+	// $GOPATH at all.
+	var importPrefix string
+	if tool == resolver.GoModules {
+		importPrefix, err = moduleImportPath(manifestDir)
+		if err != nil {
+			return Project{}, err
+		}
+	} else {
+		if os.Getenv("GOPATH") == "" {
+			return Project{}, errors.New("no $GOPATH set")
+		}
+		gopath, err := filepath.Abs(os.Getenv("GOPATH"))
+		if err != nil {
+			return Project{}, errors.Wrap(err, "could not get absolute $GOPATH")
+		}
+		importPrefix, err = filepath.Rel(filepath.Join(gopath, "src"), projectDir)
+		if err != nil {
+			return Project{}, errors.Wrap(err, "could not compute import prefix")
+		}
 	}
 
 	// Cache the computed project.
@@ -119,7 +172,7 @@ type eitherStr struct {
 }
 
 func (r *eitherStr) Bind(tool string, find func(pathElems ...string) (bool, error), pathElems ...string) {
-	if r.err != nil {
+	if r.err != nil || r.result != "" {
 		return
 	}
 
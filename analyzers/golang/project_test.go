@@ -0,0 +1,54 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleImportPath(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/fossas/fixture\n\ngo 1.14\n"), 0o600)
+	assert.NoError(t, err)
+
+	importPath, err := moduleImportPath(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/fossas/fixture", importPath)
+}
+
+func TestModuleImportPathMissingDirective(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("go 1.14\n"), 0o600)
+	assert.NoError(t, err)
+
+	_, err = moduleImportPath(dir)
+	assert.Error(t, err)
+}
+
+// TestProjectResolvesSymlinkedGopath covers a checkout that lives outside
+// $GOPATH and is symlinked into place, e.g. $GOPATH/src/example.com/foo ->
+// /home/user/code/foo. The reported import path and project directory
+// should reflect the symlinked location the user expects, not wherever the
+// symlink happens to resolve to on disk.
+func TestProjectResolvesSymlinkedGopath(t *testing.T) {
+	realRepo := t.TempDir()
+	err := os.MkdirAll(filepath.Join(realRepo, ".git"), 0o755)
+	assert.NoError(t, err)
+
+	gopath := t.TempDir()
+	err = os.MkdirAll(filepath.Join(gopath, "src", "example.com"), 0o755)
+	assert.NoError(t, err)
+	symlinked := filepath.Join(gopath, "src", "example.com", "foo")
+	err = os.Symlink(realRepo, symlinked)
+	assert.NoError(t, err)
+
+	t.Setenv("GOPATH", gopath)
+
+	a := New()
+	project, err := a.Project("example.com/foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/foo", project.ImportPath)
+	assert.Equal(t, symlinked, project.Dir)
+}
@@ -0,0 +1,316 @@
+package resolver
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/fossas/fossa-cli/files"
+)
+
+// ConstraintFromDep returns the constraint (branch, version range, or
+// revision) that a dep (Gopkg.toml) manifest at manifestDir declares for
+// importPath, by scanning its `[[constraint]]` blocks for a matching
+// `name`. It returns "" if importPath has no constraint in the manifest.
+func ConstraintFromDep(manifestDir, importPath string) (string, error) {
+	contents, err := files.Read(manifestDir, "Gopkg.toml")
+	if err != nil {
+		return "", errors.Wrap(err, "could not read Gopkg.toml")
+	}
+
+	inBlock := false
+	nameMatches := false
+	for _, raw := range strings.Split(string(contents), "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case strings.HasPrefix(line, "[[constraint]]") || strings.HasPrefix(line, "[[override]]"):
+			inBlock = true
+			nameMatches = false
+			continue
+		case strings.HasPrefix(line, "["):
+			inBlock = false
+			continue
+		}
+		if !inBlock {
+			continue
+		}
+
+		key, value, ok := splitTOMLAssignment(line)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "name":
+			nameMatches = value == importPath
+		case "branch", "version", "revision":
+			if nameMatches {
+				return value, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func splitTOMLAssignment(line string) (key, value string, ok bool) {
+	k, v, found := strings.Cut(line, "=")
+	if !found {
+		return "", "", false
+	}
+	return strings.TrimSpace(k), strings.Trim(strings.TrimSpace(v), `"`), true
+}
+
+// ConstraintFromGlide returns the version constraint that a glide
+// (glide.yaml) manifest at manifestDir declares for importPath, by
+// scanning its `- package:` / `version:` entries. It returns "" if
+// importPath has no constraint in the manifest.
+func ConstraintFromGlide(manifestDir, importPath string) (string, error) {
+	contents, err := files.Read(manifestDir, "glide.yaml")
+	if err != nil {
+		return "", errors.Wrap(err, "could not read glide.yaml")
+	}
+
+	packageMatches := false
+	for _, raw := range strings.Split(string(contents), "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case strings.HasPrefix(line, "- package:"):
+			pkg := strings.TrimSpace(strings.TrimPrefix(line, "- package:"))
+			packageMatches = pkg == importPath
+		case strings.HasPrefix(line, "version:") && packageMatches:
+			return strings.TrimSpace(strings.TrimPrefix(line, "version:")), nil
+		}
+	}
+	return "", nil
+}
+
+// LockedRevisionFromDep returns the revision that a dep (Gopkg.lock)
+// lockfile at manifestDir pins importPath to, by scanning its
+// `[[projects]]` blocks for a matching `name`. This is distinct from
+// ConstraintFromDep: Gopkg.toml declares the allowed constraint, while
+// Gopkg.lock records what that constraint actually resolved to.
+func LockedRevisionFromDep(manifestDir, importPath string) (string, error) {
+	contents, err := files.Read(manifestDir, "Gopkg.lock")
+	if err != nil {
+		return "", errors.Wrap(err, "could not read Gopkg.lock")
+	}
+
+	inBlock := false
+	nameMatches := false
+	for _, raw := range strings.Split(string(contents), "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case strings.HasPrefix(line, "[[projects]]"):
+			inBlock = true
+			nameMatches = false
+			continue
+		case strings.HasPrefix(line, "["):
+			inBlock = false
+			continue
+		}
+		if !inBlock {
+			continue
+		}
+
+		key, value, ok := splitTOMLAssignment(line)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "name":
+			nameMatches = value == importPath
+		case "revision":
+			if nameMatches {
+				return value, nil
+			}
+		}
+	}
+	return "", errors.Errorf("%s is not present in Gopkg.lock", importPath)
+}
+
+// LockedRevisionFromGlide returns the revision that a glide (glide.lock)
+// lockfile at manifestDir pins importPath to, by scanning its `- name:` /
+// `version:` entries (those under `testImports:` included). This is distinct from
+// ConstraintFromGlide: glide.yaml declares the allowed constraint, while
+// glide.lock records what that constraint actually resolved to.
+func LockedRevisionFromGlide(manifestDir, importPath string) (string, error) {
+	contents, err := files.Read(manifestDir, "glide.lock")
+	if err != nil {
+		return "", errors.Wrap(err, "could not read glide.lock")
+	}
+
+	nameMatches := false
+	for _, raw := range strings.Split(string(contents), "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case strings.HasPrefix(line, "- name:"):
+			name := strings.TrimSpace(strings.TrimPrefix(line, "- name:"))
+			nameMatches = name == importPath
+		case strings.HasPrefix(line, "version:") && nameMatches:
+			return strings.TrimSpace(strings.TrimPrefix(line, "version:")), nil
+		}
+	}
+	return "", errors.Errorf("%s is not present in glide.lock", importPath)
+}
+
+// godepsManifest mirrors the subset of Godeps/Godeps.json that we care
+// about: the locked revision for each dependency.
+type godepsManifest struct {
+	Deps []struct {
+		ImportPath string
+		Rev        string
+		Comment    string
+	}
+}
+
+// LockedRevisionFromGodep returns the revision that a godep
+// (Godeps/Godeps.json) manifest at manifestDir locks importPath to.
+// Godep has no separate "constraint" concept distinct from the locked
+// revision; the lockfile and the manifest are the same file.
+func LockedRevisionFromGodep(manifestDir, importPath string) (string, error) {
+	contents, err := files.Read(manifestDir, "Godeps", "Godeps.json")
+	if err != nil {
+		return "", errors.Wrap(err, "could not read Godeps/Godeps.json")
+	}
+
+	var manifest godepsManifest
+	if err := json.Unmarshal(contents, &manifest); err != nil {
+		return "", errors.Wrap(err, "could not parse Godeps/Godeps.json")
+	}
+
+	for _, dep := range manifest.Deps {
+		if dep.ImportPath == importPath {
+			return dep.Rev, nil
+		}
+	}
+	return "", errors.Errorf("%s is not present in Godeps/Godeps.json", importPath)
+}
+
+// vendorJSONManifest mirrors the subset of govendor's vendor/vendor.json
+// that we care about: the locked revision for each package.
+type vendorJSONManifest struct {
+	Package []struct {
+		Path     string
+		Revision string
+	}
+}
+
+// LockedRevisionFromGovendor returns the revision that a govendor
+// (vendor/vendor.json) manifest at manifestDir locks importPath to.
+func LockedRevisionFromGovendor(manifestDir, importPath string) (string, error) {
+	contents, err := files.Read(manifestDir, "vendor", "vendor.json")
+	if err != nil {
+		return "", errors.Wrap(err, "could not read vendor/vendor.json")
+	}
+
+	var manifest vendorJSONManifest
+	if err := json.Unmarshal(contents, &manifest); err != nil {
+		return "", errors.Wrap(err, "could not parse vendor/vendor.json")
+	}
+
+	for _, pkg := range manifest.Package {
+		if pkg.Path == importPath {
+			return pkg.Revision, nil
+		}
+	}
+	return "", errors.Errorf("%s is not present in vendor/vendor.json", importPath)
+}
+
+// ListDependencies enumerates the import paths declared in a manifest for
+// the given tool. This is the first step of status reporting: once we know
+// which import paths a manifest governs, the ConstraintFromX and
+// LockedRevisionFromX functions above resolve each one individually.
+func ListDependencies(tool Type, manifestDir string) ([]string, error) {
+	switch tool {
+	case Dep:
+		return listDepImportPaths(manifestDir)
+	case Glide:
+		return listGlideImportPaths(manifestDir)
+	case Godep:
+		return listGodepImportPaths(manifestDir)
+	case Govendor:
+		return listGovendorImportPaths(manifestDir)
+	default:
+		return nil, errors.Errorf("listing dependencies is not supported for tool %s", tool)
+	}
+}
+
+func listDepImportPaths(manifestDir string) ([]string, error) {
+	contents, err := files.Read(manifestDir, "Gopkg.toml")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read Gopkg.toml")
+	}
+
+	var paths []string
+	inBlock := false
+	for _, raw := range strings.Split(string(contents), "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case strings.HasPrefix(line, "[[constraint]]") || strings.HasPrefix(line, "[[override]]"):
+			inBlock = true
+			continue
+		case strings.HasPrefix(line, "["):
+			inBlock = false
+			continue
+		}
+		if !inBlock {
+			continue
+		}
+		if key, value, ok := splitTOMLAssignment(line); ok && key == "name" {
+			paths = append(paths, value)
+		}
+	}
+	return paths, nil
+}
+
+func listGlideImportPaths(manifestDir string) ([]string, error) {
+	contents, err := files.Read(manifestDir, "glide.yaml")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read glide.yaml")
+	}
+
+	var paths []string
+	for _, raw := range strings.Split(string(contents), "\n") {
+		line := strings.TrimSpace(raw)
+		if strings.HasPrefix(line, "- package:") {
+			paths = append(paths, strings.TrimSpace(strings.TrimPrefix(line, "- package:")))
+		}
+	}
+	return paths, nil
+}
+
+func listGodepImportPaths(manifestDir string) ([]string, error) {
+	contents, err := files.Read(manifestDir, "Godeps", "Godeps.json")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read Godeps/Godeps.json")
+	}
+
+	var manifest godepsManifest
+	if err := json.Unmarshal(contents, &manifest); err != nil {
+		return nil, errors.Wrap(err, "could not parse Godeps/Godeps.json")
+	}
+
+	paths := make([]string, len(manifest.Deps))
+	for i, dep := range manifest.Deps {
+		paths[i] = dep.ImportPath
+	}
+	return paths, nil
+}
+
+func listGovendorImportPaths(manifestDir string) ([]string, error) {
+	contents, err := files.Read(manifestDir, "vendor", "vendor.json")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read vendor/vendor.json")
+	}
+
+	var manifest vendorJSONManifest
+	if err := json.Unmarshal(contents, &manifest); err != nil {
+		return nil, errors.Wrap(err, "could not parse vendor/vendor.json")
+	}
+
+	paths := make([]string, len(manifest.Package))
+	for i, pkg := range manifest.Package {
+		paths[i] = pkg.Path
+	}
+	return paths, nil
+}
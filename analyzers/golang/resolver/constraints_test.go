@@ -0,0 +1,122 @@
+package resolver_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossas/fossa-cli/analyzers/golang/resolver"
+)
+
+const fixtureGopkgToml = `[[constraint]]
+  name = "github.com/pkg/errors"
+  version = "0.9.1"
+
+[[constraint]]
+  name = "golang.org/x/net"
+  branch = "master"
+`
+
+func TestConstraintFromDep(t *testing.T) {
+	dir := writeFixture(t, fixtureGopkgToml, "Gopkg.toml")
+
+	constraint, err := resolver.ConstraintFromDep(dir, "github.com/pkg/errors")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.9.1", constraint)
+
+	constraint, err = resolver.ConstraintFromDep(dir, "golang.org/x/net")
+	assert.NoError(t, err)
+	assert.Equal(t, "master", constraint)
+
+	constraint, err = resolver.ConstraintFromDep(dir, "github.com/not/present")
+	assert.NoError(t, err)
+	assert.Empty(t, constraint)
+}
+
+func TestListDependenciesDep(t *testing.T) {
+	dir := writeFixture(t, fixtureGopkgToml, "Gopkg.toml")
+
+	paths, err := resolver.ListDependencies(resolver.Dep, dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"github.com/pkg/errors", "golang.org/x/net"}, paths)
+}
+
+const fixtureGlideYaml = `package: github.com/fossas/fixture
+import:
+- package: github.com/pkg/errors
+  version: v0.9.1
+- package: golang.org/x/net
+  version: master
+`
+
+func TestConstraintFromGlide(t *testing.T) {
+	dir := writeFixture(t, fixtureGlideYaml, "glide.yaml")
+
+	constraint, err := resolver.ConstraintFromGlide(dir, "github.com/pkg/errors")
+	assert.NoError(t, err)
+	assert.Equal(t, "v0.9.1", constraint)
+}
+
+func TestListDependenciesGlide(t *testing.T) {
+	dir := writeFixture(t, fixtureGlideYaml, "glide.yaml")
+
+	paths, err := resolver.ListDependencies(resolver.Glide, dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"github.com/pkg/errors", "golang.org/x/net"}, paths)
+}
+
+func writeGodeps(t *testing.T, dir string) {
+	t.Helper()
+	err := os.MkdirAll(filepath.Join(dir, "Godeps"), 0o755)
+	assert.NoError(t, err)
+
+	manifest := map[string]interface{}{
+		"Deps": []map[string]string{
+			{"ImportPath": "github.com/pkg/errors", "Rev": "abcdef123456"},
+		},
+	}
+	contents, err := json.Marshal(manifest)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "Godeps", "Godeps.json"), contents, 0o600)
+	assert.NoError(t, err)
+}
+
+func TestLockedRevisionFromGodep(t *testing.T) {
+	dir := t.TempDir()
+	writeGodeps(t, dir)
+
+	rev, err := resolver.LockedRevisionFromGodep(dir, "github.com/pkg/errors")
+	assert.NoError(t, err)
+	assert.Equal(t, "abcdef123456", rev)
+
+	_, err = resolver.LockedRevisionFromGodep(dir, "github.com/not/present")
+	assert.Error(t, err)
+}
+
+func writeVendorJSON(t *testing.T, dir string) {
+	t.Helper()
+	err := os.MkdirAll(filepath.Join(dir, "vendor"), 0o755)
+	assert.NoError(t, err)
+
+	manifest := map[string]interface{}{
+		"package": []map[string]string{
+			{"path": "github.com/pkg/errors", "revision": "abcdef123456"},
+		},
+	}
+	contents, err := json.Marshal(manifest)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "vendor", "vendor.json"), contents, 0o600)
+	assert.NoError(t, err)
+}
+
+func TestLockedRevisionFromGovendor(t *testing.T) {
+	dir := t.TempDir()
+	writeVendorJSON(t, dir)
+
+	rev, err := resolver.LockedRevisionFromGovendor(dir, "github.com/pkg/errors")
+	assert.NoError(t, err)
+	assert.Equal(t, "abcdef123456", rev)
+}
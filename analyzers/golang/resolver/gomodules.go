@@ -0,0 +1,366 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/fossas/fossa-cli/files"
+)
+
+// listModulesTimeout bounds how long `go list -m -json all` can block on a
+// slow or unreachable module proxy, so a single hung invocation can't stall
+// a whole analysis run.
+const listModulesTimeout = 30 * time.Second
+
+// A ModuleRequirement is a single entry of a go.mod `require` block: the
+// import path of the required module and the version it is pinned to.
+type ModuleRequirement struct {
+	Path    string
+	Version string
+}
+
+// A ModuleReplacement is a single entry of a go.mod `replace` block. New is
+// either another module path (optionally pinned to NewVersion) or a local
+// filesystem path, in which case NewVersion is empty.
+type ModuleReplacement struct {
+	Old        string
+	OldVersion string
+	New        string
+	NewVersion string
+}
+
+// A GoModFile is the parsed contents of a go.mod manifest relevant to
+// dependency resolution.
+type GoModFile struct {
+	Module   string
+	Requires []ModuleRequirement
+	Replaces []ModuleReplacement
+	Excludes []ModuleRequirement
+}
+
+var pseudoVersionPattern = regexp.MustCompile(`^v[0-9]+\.[0-9]+\.[0-9]+-(?:.+\.)?(\d{14})-([0-9a-f]{12})$`)
+
+// IsPseudoVersion returns true if version is a Go pseudo-version, e.g.
+// v0.0.0-20200101120000-abcdef123456. Pseudo-versions are synthesized by
+// the `go` tool for commits that aren't tagged as a semver release.
+func IsPseudoVersion(version string) bool {
+	return pseudoVersionPattern.MatchString(version)
+}
+
+// ParseGoMod parses the go.mod file in dir, extracting the module path and
+// the require/replace/exclude directives needed to resolve dependencies.
+// It intentionally ignores directives (such as `go`) that don't affect
+// dependency resolution.
+func ParseGoMod(dir string) (GoModFile, error) {
+	contents, err := files.Read(dir, "go.mod")
+	if err != nil {
+		return GoModFile{}, errors.Wrap(err, "could not read go.mod")
+	}
+
+	var mod GoModFile
+	var block string
+	for _, raw := range strings.Split(string(contents), "\n") {
+		line := strings.TrimSpace(raw)
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" {
+			continue
+		}
+
+		switch {
+		case line == ")":
+			block = ""
+			continue
+		case strings.HasSuffix(line, "("):
+			block = strings.TrimSpace(strings.TrimSuffix(line, "("))
+			continue
+		case strings.HasPrefix(line, "module "):
+			mod.Module = strings.TrimSpace(strings.TrimPrefix(line, "module"))
+			continue
+		}
+
+		directive := block
+		entry := line
+		if directive == "" {
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+			directive = fields[0]
+			entry = strings.TrimSpace(strings.TrimPrefix(line, directive))
+		}
+
+		switch directive {
+		case "require":
+			if req, ok := parseRequireLine(entry); ok {
+				mod.Requires = append(mod.Requires, req)
+			}
+		case "exclude":
+			if req, ok := parseRequireLine(entry); ok {
+				mod.Excludes = append(mod.Excludes, req)
+			}
+		case "replace":
+			if rep, ok := parseReplaceLine(entry); ok {
+				mod.Replaces = append(mod.Replaces, rep)
+			}
+		}
+	}
+
+	return mod, nil
+}
+
+func parseRequireLine(entry string) (ModuleRequirement, bool) {
+	fields := strings.Fields(entry)
+	if len(fields) < 2 {
+		return ModuleRequirement{}, false
+	}
+	return ModuleRequirement{Path: fields[0], Version: fields[1]}, true
+}
+
+func parseReplaceLine(entry string) (ModuleReplacement, bool) {
+	lhs, rhs, ok := strings.Cut(entry, "=>")
+	if !ok {
+		return ModuleReplacement{}, false
+	}
+
+	lhsFields := strings.Fields(strings.TrimSpace(lhs))
+	rhsFields := strings.Fields(strings.TrimSpace(rhs))
+	if len(lhsFields) == 0 || len(rhsFields) == 0 {
+		return ModuleReplacement{}, false
+	}
+
+	rep := ModuleReplacement{Old: lhsFields[0], New: rhsFields[0]}
+	if len(lhsFields) > 1 {
+		rep.OldVersion = lhsFields[1]
+	}
+	if len(rhsFields) > 1 {
+		rep.NewVersion = rhsFields[1]
+	}
+	return rep, true
+}
+
+// A GoSumEntry is a single hash line of a go.sum file.
+type GoSumEntry struct {
+	Path    string
+	Version string
+	Hash    string
+}
+
+// ParseGoSum parses the go.sum file in dir, returning one entry per
+// module@version/hash line. go.sum typically lists each module twice (once
+// for the module zip, once for its go.mod), so entries are not unique by
+// Path/Version alone.
+func ParseGoSum(dir string) ([]GoSumEntry, error) {
+	contents, err := files.Read(dir, "go.sum")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read go.sum")
+	}
+
+	var entries []GoSumEntry
+	for _, line := range strings.Split(string(contents), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		entries = append(entries, GoSumEntry{Path: fields[0], Version: fields[1], Hash: fields[2]})
+	}
+	return entries, nil
+}
+
+// A ModulePackage is one entry of the JSON stream produced by
+// `go list -m -json all`.
+type ModulePackage struct {
+	Path     string
+	Version  string
+	Main     bool
+	Indirect bool
+	Replace  *ModulePackage
+}
+
+// ListModulesRunner invokes `go list -m -json all` in dir and returns its
+// raw JSON output. It's a variable, not a hardcoded call, so callers that
+// can't assume a real `go` toolchain with working module-resolution network
+// access -- such as this package's own tests, or higher-level packages
+// exercising Resolve/DiscoverModules against fixture modules that don't
+// really exist -- can substitute a fake implementation instead of relying
+// on the real command failing.
+var ListModulesRunner = func(dir string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), listModulesTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "go", "list", "-m", "-json", "all")
+	cmd.Dir = dir
+	return cmd.Output()
+}
+
+// ListModules shells out to `go list -m -json all` in dir to enumerate the
+// full transitive module graph, including modules that go.mod/go.sum don't
+// mention directly (because they're pulled in transitively and deduplicated
+// by minimal version selection). Resolve prefers this module graph over the
+// parsed go.mod, keeping only the modules named in the `require` block.
+func ListModules(dir string) ([]ModulePackage, error) {
+	out, err := ListModulesRunner(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not run `go list -m -json all`")
+	}
+
+	var modules []ModulePackage
+	decoder := json.NewDecoder(strings.NewReader(string(out)))
+	for decoder.More() {
+		var mod ModulePackage
+		if err := decoder.Decode(&mod); err != nil {
+			return nil, errors.Wrap(err, "could not decode `go list` output")
+		}
+		modules = append(modules, mod)
+	}
+	return modules, nil
+}
+
+// A LockedDependency is a single Go module dependency resolved to an exact
+// revision, after applying any `replace` directive that targets it.
+type LockedDependency struct {
+	ImportPath string // Import path as declared in the `require` block.
+	Revision   string // Resolved version or pseudo-version.
+	ReplacedBy string // Import path this dependency was replaced by (fork), if any.
+	LocalPath  string // Local filesystem path this dependency was replaced with, if any.
+	Verified   bool   // True if go.sum records a hash for this resolved revision.
+}
+
+// Resolve reads go.mod (and, if present, go.sum) in dir and returns the
+// fully-resolved set of locked dependencies: the `require` block with
+// `replace` directives applied on top, and `exclude`d versions dropped.
+//
+// The module graph as actually built by the `go` tool (via `go list -m
+// -json all`, which performs minimal version selection and applies
+// replace/exclude itself) is preferred, since it reflects what's really
+// going to be compiled. If that fails -- no network access, the module
+// cache hasn't been populated, or `go` isn't on $PATH -- Resolve falls back
+// to resolving directly from the parsed go.mod.
+//
+// go.sum is consulted to confirm that each resolved revision is recorded
+// there (LockedDependency.Verified); Resolve does not fail if go.sum is
+// missing or doesn't mention a dependency -- go modules tolerate this (e.g.
+// with GOFLAGS=-mod=mod and GONOSUMCHECK set) -- the caller decides whether
+// an unverified revision matters.
+func Resolve(dir string) ([]LockedDependency, error) {
+	mod, err := ParseGoMod(dir)
+	if err != nil {
+		return nil, err
+	}
+	sums, _ := ParseGoSum(dir)
+
+	if modules, listErr := ListModules(dir); listErr == nil {
+		return lockedDependenciesFromModuleList(mod, modules, sums), nil
+	}
+
+	return lockedDependenciesFromGoMod(mod, sums), nil
+}
+
+// verifiedInGoSum reports whether go.sum records a hash for path at version,
+// confirming the resolved revision is pinned down rather than merely named.
+func verifiedInGoSum(sums []GoSumEntry, path, version string) bool {
+	for _, sum := range sums {
+		if sum.Path == path && sum.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// lockedDependenciesFromModuleList builds the locked dependency set from
+// the output of `go list -m -json all`, restricted to modules directly
+// named in the `require` block (mod.Requires), so the reported set matches
+// what lockedDependenciesFromGoMod would report for the same project.
+func lockedDependenciesFromModuleList(mod GoModFile, modules []ModulePackage, sums []GoSumEntry) []LockedDependency {
+	required := make(map[string]bool, len(mod.Requires))
+	for _, req := range mod.Requires {
+		required[req.Path] = true
+	}
+
+	locked := make([]LockedDependency, 0, len(mod.Requires))
+	for _, m := range modules {
+		if m.Main || !required[m.Path] {
+			continue
+		}
+
+		dep := LockedDependency{ImportPath: m.Path, Revision: m.Version}
+		if m.Replace != nil {
+			if isLocalReplacement(m.Replace.Path) {
+				dep.LocalPath = m.Replace.Path
+				dep.Revision = ""
+			} else {
+				dep.ReplacedBy = m.Replace.Path
+				dep.Revision = m.Replace.Version
+			}
+		}
+		markVerified(&dep, sums)
+		locked = append(locked, dep)
+	}
+	return locked
+}
+
+// lockedDependenciesFromGoMod builds the locked dependency set directly
+// from a parsed go.mod, applying `replace` on top of `require` and
+// dropping `exclude`d versions. This is used when `go list` can't run.
+func lockedDependenciesFromGoMod(mod GoModFile, sums []GoSumEntry) []LockedDependency {
+	excluded := make(map[string]bool, len(mod.Excludes))
+	for _, ex := range mod.Excludes {
+		excluded[ex.Path+"@"+ex.Version] = true
+	}
+
+	replacements := make(map[string]ModuleReplacement, len(mod.Replaces))
+	for _, rep := range mod.Replaces {
+		replacements[rep.Old] = rep
+	}
+
+	locked := make([]LockedDependency, 0, len(mod.Requires))
+	for _, req := range mod.Requires {
+		if excluded[req.Path+"@"+req.Version] {
+			continue
+		}
+
+		dep := LockedDependency{ImportPath: req.Path, Revision: req.Version}
+		if rep, ok := replacements[req.Path]; ok {
+			if isLocalReplacement(rep.New) {
+				dep.LocalPath = rep.New
+				dep.Revision = ""
+			} else {
+				dep.ReplacedBy = rep.New
+				dep.Revision = rep.NewVersion
+			}
+		}
+		markVerified(&dep, sums)
+		locked = append(locked, dep)
+	}
+	return locked
+}
+
+// markVerified sets dep.Verified if go.sum records a hash for the revision
+// dep actually resolves to -- the fork's path/version if it was replaced by
+// one, otherwise its own. Local-path replacements have no revision to
+// verify and are left false.
+func markVerified(dep *LockedDependency, sums []GoSumEntry) {
+	if dep.LocalPath != "" {
+		return
+	}
+	verifyPath, verifyVersion := dep.ImportPath, dep.Revision
+	if dep.ReplacedBy != "" {
+		verifyPath = dep.ReplacedBy
+	}
+	dep.Verified = verifiedInGoSum(sums, verifyPath, verifyVersion)
+}
+
+// isLocalReplacement returns true if a go.mod `replace` target is a local
+// filesystem path rather than another module path. Per the go.mod spec,
+// local replacements always start with "./" or "../", or are absolute.
+func isLocalReplacement(target string) bool {
+	return strings.HasPrefix(target, "./") || strings.HasPrefix(target, "../") || filepath.IsAbs(target)
+}
@@ -0,0 +1,159 @@
+package resolver_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossas/fossa-cli/analyzers/golang/resolver"
+)
+
+// TestMain forces Resolve down its go.mod-only fallback path for this
+// package's whole test suite, instead of depending on the real `go` tool
+// failing to resolve fixture modules that don't exist -- that reliance on
+// an external command's network/module-cache behavior isn't a proper test
+// boundary.
+func TestMain(m *testing.M) {
+	resolver.ListModulesRunner = func(dir string) ([]byte, error) {
+		return nil, errors.New("go list disabled in tests")
+	}
+	os.Exit(m.Run())
+}
+
+const fixtureGoMod = `module github.com/fossas/fixture
+
+go 1.14
+
+require (
+	github.com/pkg/errors v0.9.1
+	golang.org/x/net v0.0.0-20200101120000-abcdef123456
+)
+
+exclude github.com/bad/pkg v1.0.0
+
+replace github.com/fossas/fixture/sub => ../sub
+`
+
+const fixtureGoSum = `github.com/pkg/errors v0.9.1 h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=
+github.com/pkg/errors v0.9.1/go.mod h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=
+`
+
+func writeFixture(t *testing.T, contents, name string) string {
+	t.Helper()
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o600)
+	assert.NoError(t, err)
+	return dir
+}
+
+func TestParseGoMod(t *testing.T) {
+	dir := writeFixture(t, fixtureGoMod, "go.mod")
+
+	mod, err := resolver.ParseGoMod(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/fossas/fixture", mod.Module)
+
+	assert.Len(t, mod.Requires, 2)
+	assert.Equal(t, resolver.ModuleRequirement{Path: "github.com/pkg/errors", Version: "v0.9.1"}, mod.Requires[0])
+	assert.Equal(t, resolver.ModuleRequirement{Path: "golang.org/x/net", Version: "v0.0.0-20200101120000-abcdef123456"}, mod.Requires[1])
+
+	assert.Len(t, mod.Excludes, 1)
+	assert.Equal(t, "github.com/bad/pkg", mod.Excludes[0].Path)
+
+	assert.Len(t, mod.Replaces, 1)
+	assert.Equal(t, "github.com/fossas/fixture/sub", mod.Replaces[0].Old)
+	assert.Equal(t, "../sub", mod.Replaces[0].New)
+}
+
+func TestParseGoSum(t *testing.T) {
+	dir := writeFixture(t, fixtureGoSum, "go.sum")
+
+	entries, err := resolver.ParseGoSum(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "github.com/pkg/errors", entries[0].Path)
+	assert.Equal(t, "v0.9.1", entries[0].Version)
+}
+
+func TestIsPseudoVersion(t *testing.T) {
+	assert.True(t, resolver.IsPseudoVersion("v0.0.0-20200101120000-abcdef123456"))
+	assert.False(t, resolver.IsPseudoVersion("v1.2.3"))
+	assert.False(t, resolver.IsPseudoVersion("not-a-version"))
+}
+
+const fixtureGoModWithReplaces = `module github.com/fossas/fixture
+
+go 1.14
+
+require (
+	github.com/pkg/errors v0.9.1
+	github.com/fossas/fixture/sub v1.0.0
+	github.com/some/upstream v1.2.3
+)
+
+replace github.com/fossas/fixture/sub => ../sub
+
+replace github.com/some/upstream => github.com/someone/upstream-fork v1.2.3-patched
+`
+
+func TestResolveAppliesReplaces(t *testing.T) {
+	dir := writeFixture(t, fixtureGoModWithReplaces, "go.mod")
+
+	deps, err := resolver.Resolve(dir)
+	assert.NoError(t, err)
+	assert.Len(t, deps, 3)
+
+	assert.Equal(t, resolver.LockedDependency{ImportPath: "github.com/pkg/errors", Revision: "v0.9.1"}, deps[0])
+
+	// Replaced with a local filesystem path: no revision, just where to find it.
+	assert.Equal(t, "github.com/fossas/fixture/sub", deps[1].ImportPath)
+	assert.Equal(t, "../sub", deps[1].LocalPath)
+	assert.Empty(t, deps[1].Revision)
+
+	// Replaced with a fork: resolve to the fork's import path and revision.
+	assert.Equal(t, "github.com/some/upstream", deps[2].ImportPath)
+	assert.Equal(t, "github.com/someone/upstream-fork", deps[2].ReplacedBy)
+	assert.Equal(t, "v1.2.3-patched", deps[2].Revision)
+}
+
+const fixtureGoModWithExclude = `module github.com/fossas/fixture
+
+go 1.14
+
+require (
+	github.com/pkg/errors v0.9.1
+	github.com/bad/pkg v1.0.0
+)
+
+exclude github.com/bad/pkg v1.0.0
+`
+
+func TestResolveDropsExcluded(t *testing.T) {
+	dir := writeFixture(t, fixtureGoModWithExclude, "go.mod")
+
+	deps, err := resolver.Resolve(dir)
+	assert.NoError(t, err)
+	assert.Len(t, deps, 1)
+	assert.Equal(t, "github.com/pkg/errors", deps[0].ImportPath)
+}
+
+func TestResolveMarksGoSumVerifiedDependencies(t *testing.T) {
+	dir := writeFixture(t, fixtureGoMod, "go.mod")
+	err := os.WriteFile(filepath.Join(dir, "go.sum"), []byte(fixtureGoSum), 0o600)
+	assert.NoError(t, err)
+
+	deps, err := resolver.Resolve(dir)
+	assert.NoError(t, err)
+	assert.Len(t, deps, 2)
+
+	assert.Equal(t, "github.com/pkg/errors", deps[0].ImportPath)
+	assert.True(t, deps[0].Verified)
+
+	// golang.org/x/net has no matching go.sum entry in this fixture, so it
+	// can't be confirmed.
+	assert.Equal(t, "golang.org/x/net", deps[1].ImportPath)
+	assert.False(t, deps[1].Verified)
+}
@@ -0,0 +1,50 @@
+// Package resolver identifies which Go dependency management tool governs
+// a project and resolves that tool's manifest into locked dependencies.
+package resolver
+
+// Type identifies a Go dependency management tool.
+type Type int
+
+const (
+	// Gopath indicates that no dependency manifest was found, and the
+	// project's dependencies are resolved via plain $GOPATH semantics.
+	Gopath Type = iota
+	// Dep indicates a github.com/golang/dep project (Gopkg.toml/Gopkg.lock).
+	Dep
+	// Godep indicates a github.com/tools/godep project (Godeps/Godeps.json).
+	Godep
+	// Govendor indicates a github.com/kardianos/govendor project (vendor/vendor.json).
+	Govendor
+	// Glide indicates a github.com/Masterminds/glide project (glide.yaml/glide.lock).
+	Glide
+	// Vndr indicates a github.com/LK4D4/vndr project (vendor.conf).
+	Vndr
+	// Gdm indicates a github.com/sparrc/gdm project (Godeps file).
+	Gdm
+	// GoModules indicates a Go modules project (go.mod/go.sum), as
+	// introduced in Go 1.11.
+	GoModules
+)
+
+// String returns the canonical name of the dependency management tool, as
+// used in log messages and by the golang analyzer's NearestLockfile.
+func (t Type) String() string {
+	switch t {
+	case Dep:
+		return "dep"
+	case Godep:
+		return "godep"
+	case Govendor:
+		return "govendor"
+	case Glide:
+		return "glide"
+	case Vndr:
+		return "vndr"
+	case Gdm:
+		return "gdm"
+	case GoModules:
+		return "gomodules"
+	default:
+		return "gopath"
+	}
+}
@@ -0,0 +1,103 @@
+package resolver
+
+import (
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/fossas/fossa-cli/vcs"
+)
+
+// goImportHTTPClient bounds how long a vanity import lookup can block on an
+// unresponsive host, so a single slow or dead module server can't hang a
+// whole analysis run.
+var goImportHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// A RepoRoot is the result of resolving a Go import path to the version
+// control repository that contains it, per the "remote import path
+// discovery" protocol described at https://golang.org/cmd/go/#hdr-Remote_import_paths.
+type RepoRoot struct {
+	Root string   // The import path prefix that corresponds to the repository root.
+	VCS  vcs.Type // The VCS that hosts the repository.
+	URL  string   // The repository's URL.
+}
+
+var wellKnownHosts = map[string]int{
+	"github.com":    2,
+	"gitlab.com":    2,
+	"bitbucket.org": 2,
+}
+
+// RepoRootForImportPath resolves an import path to the repository that
+// contains it. Well-known hosts (github.com, gitlab.com, bitbucket.org) are
+// resolved by path convention; anything else is treated as a vanity import
+// and resolved by fetching "https://<host>/<path>?go-get=1" and parsing its
+// `<meta name="go-import">` tag, exactly as the `go` tool itself does.
+func RepoRootForImportPath(importPath string) (RepoRoot, error) {
+	host := importPath
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+
+	if depth, ok := wellKnownHosts[host]; ok {
+		elems := strings.Split(importPath, "/")
+		if len(elems) < depth+1 {
+			return RepoRoot{}, errors.Errorf("import path %q is too short for host %s", importPath, host)
+		}
+		root := strings.Join(elems[:depth+1], "/")
+		return RepoRoot{Root: root, VCS: vcs.Git, URL: "https://" + root}, nil
+	}
+
+	return fetchGoImportMeta(importPath)
+}
+
+var goImportMetaPattern = regexp.MustCompile(`<meta\s+name=["']go-import["']\s+content=["']([^"']+)["']\s*/?>`)
+
+// fetchPage is the page fetcher used by fetchGoImportMeta, as a variable so
+// tests can substitute it for a local httptest server instead of making a
+// real network request.
+var fetchPage = func(url string) (io.ReadCloser, error) {
+	resp, err := goImportHTTPClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// fetchGoImportMeta implements vanity import resolution: fetch the page at
+// https://<importPath>?go-get=1 and look for a `<meta name="go-import"
+// content="<root> <vcs> <repoURL>">` tag.
+func fetchGoImportMeta(importPath string) (RepoRoot, error) {
+	body, err := fetchPage("https://" + importPath + "?go-get=1")
+	if err != nil {
+		return RepoRoot{}, errors.Wrapf(err, "could not fetch go-import meta tag for %s", importPath)
+	}
+	defer body.Close()
+
+	contents, err := io.ReadAll(body)
+	if err != nil {
+		return RepoRoot{}, errors.Wrapf(err, "could not read go-import response for %s", importPath)
+	}
+
+	return parseGoImportMeta(importPath, string(contents))
+}
+
+// parseGoImportMeta extracts the go-import meta tag from an HTML page
+// fetched for importPath, per https://golang.org/cmd/go/#hdr-Remote_import_paths.
+func parseGoImportMeta(importPath, html string) (RepoRoot, error) {
+	match := goImportMetaPattern.FindStringSubmatch(html)
+	if match == nil {
+		return RepoRoot{}, errors.Errorf("no go-import meta tag found for %s", importPath)
+	}
+
+	fields := strings.Fields(match[1])
+	if len(fields) != 3 {
+		return RepoRoot{}, errors.Errorf("malformed go-import meta tag for %s: %q", importPath, match[1])
+	}
+
+	return RepoRoot{Root: fields[0], VCS: vcs.Type(fields[1]), URL: fields[2]}, nil
+}
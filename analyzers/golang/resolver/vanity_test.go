@@ -0,0 +1,51 @@
+package resolver
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fossas/fossa-cli/vcs"
+)
+
+func TestRepoRootForImportPathWellKnownHost(t *testing.T) {
+	root, err := RepoRootForImportPath("github.com/fossas/fossa-cli/analyzers/golang")
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/fossas/fossa-cli", root.Root)
+	assert.Equal(t, vcs.Git, root.VCS)
+	assert.Equal(t, "https://github.com/fossas/fossa-cli", root.URL)
+}
+
+func TestParseGoImportMeta(t *testing.T) {
+	html := `<html><head>
+<meta name="go-import" content="example.org/fixture git https://github.com/fossas/fixture">
+</head></html>`
+
+	root, err := parseGoImportMeta("example.org/fixture", html)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.org/fixture", root.Root)
+	assert.Equal(t, vcs.Git, root.VCS)
+	assert.Equal(t, "https://github.com/fossas/fixture", root.URL)
+}
+
+func TestParseGoImportMetaMissingTag(t *testing.T) {
+	_, err := parseGoImportMeta("example.org/fixture", `<html></html>`)
+	assert.Error(t, err)
+}
+
+func TestRepoRootForImportPathVanity(t *testing.T) {
+	original := fetchPage
+	defer func() { fetchPage = original }()
+	fetchPage = func(url string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(
+			`<meta name="go-import" content="example.org/fixture git https://github.com/fossas/fixture">`,
+		)), nil
+	}
+
+	root, err := RepoRootForImportPath("example.org/fixture")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.org/fixture", root.Root)
+	assert.Equal(t, "https://github.com/fossas/fixture", root.URL)
+}
@@ -0,0 +1,249 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/fossas/fossa-cli/analyzers/golang/resolver"
+	"github.com/fossas/fossa-cli/vcs"
+)
+
+// A DependencyStatus reports, for a single dependency, how the manifest's
+// declared constraint, the lockfile's locked revision, and the latest
+// revision available upstream compare to each other and to the working
+// copy actually present on disk.
+type DependencyStatus struct {
+	ImportPath string // Root import path of the dependency.
+	Constraint string // Constraint declared in the manifest, if any.
+	Locked     string // Revision the lockfile pins this dependency to.
+	Latest     string // Latest tag (or, failing that, commit) available upstream.
+	WorkingDir string // Local working copy inspected for Dirty/Stale, if one was found.
+	Dirty      bool   // True if the working copy has uncommitted changes.
+	Stale      bool   // True if the working copy's checked-out revision doesn't match Locked. Best-effort: a Locked tag that can't be resolved against upstream (e.g. no network) is never reported Stale.
+	UpToDate   bool   // True if Locked matches the latest upstream tag or the upstream default branch's head commit.
+	Verified   bool   // True if go.sum records a hash for Locked (go-modules projects only; see resolver.LockedDependency.Verified).
+}
+
+// Status reports the constraint, locked revision, and latest upstream
+// revision of every dependency declared in the project's manifest, as well
+// as whether the working copy on disk matches what's locked.
+//
+// Go modules projects are reported with Constraint from the `require`
+// block and Locked from Resolve (after `replace` is applied); legacy tool
+// projects read the constraint and the lockfile's locked revision from
+// separate files, since e.g. Gopkg.toml's declared constraint and
+// Gopkg.lock's resolved revision are not the same thing.
+func (a *Analyzer) Status(pkg string) ([]DependencyStatus, error) {
+	project, err := a.Project(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.Tool == resolver.GoModules {
+		return a.moduleStatus(project)
+	}
+	return a.legacyStatus(project)
+}
+
+func (a *Analyzer) moduleStatus(project Project) ([]DependencyStatus, error) {
+	mod, err := resolver.ParseGoMod(project.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	required := make(map[string]string, len(mod.Requires))
+	for _, req := range mod.Requires {
+		required[req.Path] = req.Version
+	}
+
+	deps, err := resolver.Resolve(project.Manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]DependencyStatus, len(deps))
+	for i, dep := range deps {
+		importPath := dep.ImportPath
+		if dep.ReplacedBy != "" {
+			importPath = dep.ReplacedBy
+		}
+
+		status := DependencyStatus{
+			ImportPath: dep.ImportPath,
+			Constraint: required[dep.ImportPath],
+			Locked:     dep.Revision,
+			Verified:   dep.Verified,
+		}
+		if dep.LocalPath == "" {
+			repoRoot, _ := applyUpstreamStatus(&status, importPath)
+			if dir, dirty, stale, ok := workingCopyStatus(project, importPath, dep.Revision, repoRoot); ok {
+				status.WorkingDir = dir
+				status.Dirty = dirty
+				status.Stale = stale
+			}
+		}
+		statuses[i] = status
+	}
+	return statuses, nil
+}
+
+func (a *Analyzer) legacyStatus(project Project) ([]DependencyStatus, error) {
+	importPaths, err := resolver.ListDependencies(project.Tool, project.Manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]DependencyStatus, len(importPaths))
+	for i, importPath := range importPaths {
+		constraint, locked, err := legacyConstraintAndLock(project.Tool, project.Manifest, importPath)
+		if err != nil {
+			return nil, err
+		}
+
+		status := DependencyStatus{
+			ImportPath: importPath,
+			Constraint: constraint,
+			Locked:     locked,
+		}
+		repoRoot, _ := applyUpstreamStatus(&status, importPath)
+
+		if dir, dirty, stale, ok := workingCopyStatus(project, importPath, locked, repoRoot); ok {
+			status.WorkingDir = dir
+			status.Dirty = dirty
+			status.Stale = stale
+		}
+		statuses[i] = status
+	}
+	return statuses, nil
+}
+
+func legacyConstraintAndLock(tool resolver.Type, manifestDir, importPath string) (constraint string, locked string, err error) {
+	switch tool {
+	case resolver.Dep:
+		constraint, err = resolver.ConstraintFromDep(manifestDir, importPath)
+		if err == nil {
+			locked, err = resolver.LockedRevisionFromDep(manifestDir, importPath)
+		}
+	case resolver.Glide:
+		constraint, err = resolver.ConstraintFromGlide(manifestDir, importPath)
+		if err == nil {
+			locked, err = resolver.LockedRevisionFromGlide(manifestDir, importPath)
+		}
+	case resolver.Godep:
+		locked, err = resolver.LockedRevisionFromGodep(manifestDir, importPath)
+	case resolver.Govendor:
+		locked, err = resolver.LockedRevisionFromGovendor(manifestDir, importPath)
+	default:
+		err = errors.Errorf("status reporting is not supported for tool %s", tool)
+	}
+	return constraint, locked, err
+}
+
+// applyUpstreamStatus resolves importPath's repository and fills in
+// status.Latest and status.UpToDate. It's best-effort: a dependency whose
+// upstream can't be reached is left with Latest empty rather than failing
+// the whole status report. The resolved RepoRoot is returned (with ok true)
+// whenever resolution itself succeeded, even if the subsequent tag/head
+// lookups didn't, so callers can reuse it (e.g. workingCopyStatus resolving
+// a tagged Locked revision) without resolving importPath a second time.
+func applyUpstreamStatus(status *DependencyStatus, importPath string) (repoRoot resolver.RepoRoot, ok bool) {
+	repoRoot, err := resolver.RepoRootForImportPath(importPath)
+	if err != nil {
+		return resolver.RepoRoot{}, false
+	}
+
+	tag, _ := repoRoot.VCS.LatestTag(repoRoot.URL)
+	head, headErr := repoRoot.VCS.RemoteHead(repoRoot.URL)
+	if tag == "" && headErr != nil {
+		return repoRoot, true
+	}
+
+	status.Latest = tag
+	if status.Latest == "" {
+		status.Latest = head
+	}
+	status.UpToDate = status.Locked != "" && (status.Locked == tag || status.Locked == head || revisionMatchesPseudoVersion(status.Locked, head))
+	return repoRoot, true
+}
+
+// revisionMatchesPseudoVersion reports whether locked is a Go pseudo-version
+// (e.g. v0.0.0-20200101120000-abcdef123456) whose embedded commit hash is a
+// prefix of head. Pseudo-versions embed a 12-character abbreviation of the
+// commit they were generated from, so a direct string comparison against a
+// full SHA returned by RemoteHead would never match even when the revision
+// is in fact current.
+func revisionMatchesPseudoVersion(locked, head string) bool {
+	if head == "" || !resolver.IsPseudoVersion(locked) {
+		return false
+	}
+	hash := locked[strings.LastIndex(locked, "-")+1:]
+	return strings.HasPrefix(head, hash)
+}
+
+var commitHashPattern = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// revisionMatchesHead reports whether locked (a go.mod require version, which
+// may be a semver tag, a pseudo-version, or a raw commit hash) is satisfied
+// by head, the working copy's checked-out commit. A semver tag isn't
+// directly comparable to a commit hash, so it's resolved to the commit it
+// points to at the upstream repository (repoRoot) via Type.TagCommit. If
+// that can't be done either -- repoRoot wasn't resolved, or the remote
+// lookup fails -- locked is treated as a match rather than risking a false
+// "stale", consistent with applyUpstreamStatus's best-effort treatment of
+// cases it can't resolve.
+func revisionMatchesHead(locked, head string, repoRoot resolver.RepoRoot) bool {
+	if locked == "" || head == "" {
+		return true
+	}
+	if resolver.IsPseudoVersion(locked) {
+		return revisionMatchesPseudoVersion(locked, head)
+	}
+	if commitHashPattern.MatchString(locked) {
+		return strings.HasPrefix(head, locked) || strings.HasPrefix(locked, head)
+	}
+	if repoRoot.VCS != "" {
+		if tagCommit, err := repoRoot.VCS.TagCommit(repoRoot.URL, locked); err == nil {
+			return tagCommit == head
+		}
+	}
+	return true
+}
+
+// workingCopyStatus reports whether the checked-out copy of importPath
+// (under $GOPATH/src, or vendored alongside the manifest) has uncommitted
+// changes, and whether its checked-out revision (vcs.Type.Head) matches
+// locked (see revisionMatchesHead; repoRoot, from applyUpstreamStatus, lets
+// a tagged locked revision be resolved remotely). ok is false if no working
+// copy could be found to inspect.
+func workingCopyStatus(project Project, importPath, locked string, repoRoot resolver.RepoRoot) (workingDir string, dirty bool, stale bool, ok bool) {
+	candidates := []string{filepath.Join(project.Manifest, "vendor", importPath)}
+	if gopath := os.Getenv("GOPATH"); gopath != "" {
+		candidates = append(candidates, filepath.Join(gopath, "src", importPath))
+	}
+
+	for _, dir := range candidates {
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		vcsType, err := vcs.Detect(dir)
+		if err != nil {
+			continue
+		}
+		isDirty, err := vcsType.IsDirty(dir)
+		if err != nil {
+			continue
+		}
+
+		isStale := false
+		if head, err := vcsType.Head(dir); err == nil {
+			isStale = !revisionMatchesHead(locked, head, repoRoot)
+		}
+
+		return dir, isDirty, isStale, true
+	}
+	return "", false, false, false
+}
@@ -0,0 +1,136 @@
+package golang
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleStatusLocalReplacement(t *testing.T) {
+	dir := t.TempDir()
+	contents := `module github.com/fossas/fixture
+
+go 1.14
+
+require github.com/fossas/fixture/sub v1.0.0
+
+replace github.com/fossas/fixture/sub => ../sub
+`
+	err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(contents), 0o600)
+	assert.NoError(t, err)
+
+	a := New()
+	statuses, err := a.moduleStatus(Project{Manifest: dir, ImportPath: "github.com/fossas/fixture"})
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+
+	status := statuses[0]
+	assert.Equal(t, "github.com/fossas/fixture/sub", status.ImportPath)
+	assert.Equal(t, "v1.0.0", status.Constraint)
+	// Replaced with a local filesystem path, so there's no locked revision
+	// to report, and no latest revision lookup is attempted.
+	assert.Empty(t, status.Locked)
+	assert.Empty(t, status.Latest)
+	assert.False(t, status.UpToDate)
+}
+
+func TestModuleStatusReportsStaleWorkingCopy(t *testing.T) {
+	gopath := t.TempDir()
+	importPath := "example.com/stale"
+	workDir := filepath.Join(gopath, "src", importPath)
+	assert.NoError(t, os.MkdirAll(workDir, 0o755))
+	runGit(t, workDir, "init")
+	assert.NoError(t, os.WriteFile(filepath.Join(workDir, "fixture.go"), []byte("package stale\n"), 0o600))
+	runGit(t, workDir, "add", ".")
+	runGit(t, workDir, "commit", "-m", "initial")
+
+	t.Setenv("GOPATH", gopath)
+
+	dir := t.TempDir()
+	contents := "module github.com/fossas/fixture\n\ngo 1.14\n\nrequire " + importPath + " v0.0.0-20200101000000-abcdef123456\n"
+	err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(contents), 0o600)
+	assert.NoError(t, err)
+
+	a := New()
+	statuses, err := a.moduleStatus(Project{Manifest: dir, Dir: dir, ImportPath: "github.com/fossas/fixture"})
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+
+	status := statuses[0]
+	assert.Equal(t, importPath, status.ImportPath)
+	assert.Equal(t, workDir, status.WorkingDir)
+	assert.False(t, status.Dirty)
+	assert.True(t, status.Stale)
+}
+
+func TestModuleStatusDoesNotFlagTaggedDependencyAsStale(t *testing.T) {
+	gopath := t.TempDir()
+	importPath := "example.com/tagged"
+	workDir := filepath.Join(gopath, "src", importPath)
+	assert.NoError(t, os.MkdirAll(workDir, 0o755))
+	runGit(t, workDir, "init")
+	assert.NoError(t, os.WriteFile(filepath.Join(workDir, "fixture.go"), []byte("package tagged\n"), 0o600))
+	runGit(t, workDir, "add", ".")
+	runGit(t, workDir, "commit", "-m", "initial")
+
+	t.Setenv("GOPATH", gopath)
+
+	dir := t.TempDir()
+	// example.com/tagged isn't a real, reachable host, so resolving the tag
+	// "v0.9.1" to a commit (via RepoRootForImportPath + Type.TagCommit) is
+	// expected to fail -- this should be treated as unresolvable rather than
+	// stale, not as a mismatch against the working copy's checked-out SHA.
+	contents := "module github.com/fossas/fixture\n\ngo 1.14\n\nrequire " + importPath + " v0.9.1\n"
+	err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(contents), 0o600)
+	assert.NoError(t, err)
+
+	a := New()
+	statuses, err := a.moduleStatus(Project{Manifest: dir, Dir: dir, ImportPath: "github.com/fossas/fixture"})
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+
+	status := statuses[0]
+	assert.Equal(t, workDir, status.WorkingDir)
+	assert.False(t, status.Stale)
+}
+
+func TestModuleStatusSurfacesGoSumVerification(t *testing.T) {
+	dir := t.TempDir()
+	contents := "module github.com/fossas/fixture\n\ngo 1.14\n\nrequire (\n\tgithub.com/pkg/errors v0.9.1\n\tgolang.org/x/net v0.0.0-20200101120000-abcdef123456\n)\n"
+	err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(contents), 0o600)
+	assert.NoError(t, err)
+
+	// go.sum only has a hash for github.com/pkg/errors, so golang.org/x/net
+	// should come back unverified.
+	sum := "github.com/pkg/errors v0.9.1 h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=\n" +
+		"github.com/pkg/errors v0.9.1/go.mod h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=\n"
+	err = os.WriteFile(filepath.Join(dir, "go.sum"), []byte(sum), 0o600)
+	assert.NoError(t, err)
+
+	a := New()
+	statuses, err := a.moduleStatus(Project{Manifest: dir, ImportPath: "github.com/fossas/fixture"})
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 2)
+
+	verified := make(map[string]bool, len(statuses))
+	for _, status := range statuses {
+		verified[status.ImportPath] = status.Verified
+	}
+	assert.True(t, verified["github.com/pkg/errors"])
+	assert.False(t, verified["golang.org/x/net"])
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=fossa-test", "GIT_AUTHOR_EMAIL=test@fossa.example",
+		"GIT_COMMITTER_NAME=fossa-test", "GIT_COMMITTER_EMAIL=test@fossa.example",
+	)
+	out, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(out))
+}
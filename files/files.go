@@ -0,0 +1,41 @@
+// Package files provides helpers for common filesystem checks used
+// throughout the analyzers.
+package files
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Exists returns true if a file exists at the path formed by joining
+// pathElems.
+func Exists(pathElems ...string) (bool, error) {
+	path := filepath.Join(pathElems...)
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// ExistsFolder returns true if a folder exists at the path formed by
+// joining pathElems.
+func ExistsFolder(pathElems ...string) (bool, error) {
+	path := filepath.Join(pathElems...)
+	info, err := os.Stat(path)
+	if err == nil {
+		return info.IsDir(), nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// Read reads the contents of the file formed by joining pathElems.
+func Read(pathElems ...string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(pathElems...))
+}
@@ -27,6 +27,14 @@ func DependencyReport(projectDir string, args []string) (string, error) {
 	return runfossa(projectDir, args)
 }
 
+var statusCmd = []string{"status"}
+
+// Status executes fossa status in the provided directory.
+func Status(projectDir string, args []string) (string, error) {
+	args = append(statusCmd, args...)
+	return runfossa(projectDir, args)
+}
+
 func runfossa(projectDir string, argv []string) (string, error) {
 	cmd := exec.Cmd{
 		Argv:    argv,
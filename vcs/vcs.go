@@ -0,0 +1,172 @@
+// Package vcs provides a thin abstraction over the version control tools
+// (git, hg, bzr, svn) that Go dependency management tools rely on to
+// identify revisions, so callers don't need to special-case each tool's
+// command-line interface.
+package vcs
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/fossas/fossa-cli/files"
+)
+
+// commandTimeout bounds how long a single VCS command can block on a slow
+// or unreachable remote, so one stuck invocation can't hang a whole
+// analysis run. Mirrors resolver.listModulesTimeout, the same lesson
+// applied to the other shell-out-to-an-external-tool path in this series.
+const commandTimeout = 30 * time.Second
+
+// A Type identifies a version control system.
+type Type string
+
+const (
+	Git Type = "git"
+	Hg  Type = "hg"
+	Bzr Type = "bzr"
+	Svn Type = "svn"
+)
+
+// Detect returns the Type of the VCS repository rooted at dir, by checking
+// for each tool's metadata folder in turn.
+func Detect(dir string) (Type, error) {
+	for _, t := range []Type{Git, Hg, Bzr, Svn} {
+		ok, err := files.ExistsFolder(dir, "."+string(t))
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return t, nil
+		}
+	}
+	return "", errors.Errorf("no VCS repository found at %s", dir)
+}
+
+// Head returns the revision of the working copy at dir.
+func (t Type) Head(dir string) (string, error) {
+	switch t {
+	case Git:
+		return runIn(dir, "git", "rev-parse", "HEAD")
+	case Hg:
+		return runIn(dir, "hg", "id", "-i")
+	case Bzr:
+		return runIn(dir, "bzr", "revno")
+	case Svn:
+		return runIn(dir, "svnversion")
+	default:
+		return "", errors.Errorf("unknown VCS type %q", t)
+	}
+}
+
+// IsDirty returns true if the working copy at dir has uncommitted changes.
+func (t Type) IsDirty(dir string) (bool, error) {
+	switch t {
+	case Git:
+		out, err := runIn(dir, "git", "status", "--porcelain")
+		return out != "", err
+	case Hg:
+		out, err := runIn(dir, "hg", "status")
+		return out != "", err
+	case Bzr:
+		out, err := runIn(dir, "bzr", "status")
+		return out != "", err
+	case Svn:
+		out, err := runIn(dir, "svn", "status")
+		return out != "", err
+	default:
+		return false, errors.Errorf("unknown VCS type %q", t)
+	}
+}
+
+// RemoteHead returns the revision of the default branch at the given
+// remote repository URL, without needing a local clone.
+func (t Type) RemoteHead(repoURL string) (string, error) {
+	switch t {
+	case Git:
+		out, err := runIn("", "git", "ls-remote", repoURL, "HEAD")
+		if err != nil {
+			return "", err
+		}
+		fields := strings.Fields(out)
+		if len(fields) == 0 {
+			return "", errors.Errorf("could not parse `git ls-remote` output for %s", repoURL)
+		}
+		return fields[0], nil
+	default:
+		return "", errors.Errorf("remote head lookup is not supported for VCS type %q", t)
+	}
+}
+
+// LatestTag returns the most recent semver-like tag at the given remote
+// repository URL, without needing a local clone. It returns "" (with no
+// error) if the repository has no tags.
+func (t Type) LatestTag(repoURL string) (string, error) {
+	switch t {
+	case Git:
+		out, err := runIn("", "git", "ls-remote", "--tags", "--sort=-v:refname", repoURL)
+		if err != nil {
+			return "", err
+		}
+		for _, line := range strings.Split(out, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) != 2 {
+				continue
+			}
+			ref := strings.TrimSuffix(strings.TrimPrefix(fields[1], "refs/tags/"), "^{}")
+			if ref != "" {
+				return ref, nil
+			}
+		}
+		return "", nil
+	default:
+		return "", errors.Errorf("latest tag lookup is not supported for VCS type %q", t)
+	}
+}
+
+// TagCommit returns the commit that tag points to at the given remote
+// repository URL, resolving annotated tags to the commit they reference
+// rather than the tag object itself.
+func (t Type) TagCommit(repoURL, tag string) (string, error) {
+	switch t {
+	case Git:
+		out, err := runIn("", "git", "ls-remote", repoURL, "refs/tags/"+tag, "refs/tags/"+tag+"^{}")
+		if err != nil {
+			return "", err
+		}
+
+		var plain string
+		for _, line := range strings.Split(out, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) != 2 {
+				continue
+			}
+			if strings.HasSuffix(fields[1], "^{}") {
+				return fields[0], nil
+			}
+			plain = fields[0]
+		}
+		if plain == "" {
+			return "", errors.Errorf("tag %q not found at %s", tag, repoURL)
+		}
+		return plain, nil
+	default:
+		return "", errors.Errorf("tag resolution is not supported for VCS type %q", t)
+	}
+}
+
+func runIn(dir string, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "could not run `%s %s`", name, strings.Join(args, " "))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
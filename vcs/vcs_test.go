@@ -0,0 +1,76 @@
+package vcs
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RemoteHead, LatestTag, and TagCommit all require a reachable remote and
+// aren't covered directly here. RemoteHead and LatestTag are reached only
+// indirectly, through analyzers/golang/status_test.go's
+// TestModuleStatusSurfacesGoSumVerification; no test reaches TagCommit.
+
+func TestDetectFindsGitRepo(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+
+	detected, err := Detect(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, Git, detected)
+}
+
+func TestDetectErrorsWithNoVCS(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Detect(dir)
+	assert.Error(t, err)
+}
+
+func TestHeadReturnsCurrentCommit(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "fixture.txt"), []byte("hello\n"), 0o600))
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "initial")
+
+	head, err := Git.Head(dir)
+	assert.NoError(t, err)
+
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	assert.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(string(out)), head)
+}
+
+func TestIsDirtyReportsUncommittedChanges(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "fixture.txt"), []byte("hello\n"), 0o600))
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "initial")
+
+	clean, err := Git.IsDirty(dir)
+	assert.NoError(t, err)
+	assert.False(t, clean)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "fixture.txt"), []byte("changed\n"), 0o600))
+	dirty, err := Git.IsDirty(dir)
+	assert.NoError(t, err)
+	assert.True(t, dirty)
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=fossa-test", "GIT_AUTHOR_EMAIL=test@fossa.example",
+		"GIT_COMMITTER_NAME=fossa-test", "GIT_COMMITTER_EMAIL=test@fossa.example",
+	)
+	out, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(out))
+}